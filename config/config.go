@@ -0,0 +1,104 @@
+package config
+
+import (
+	"flag"
+	"time"
+)
+
+const VERSION = "1.6.0"
+
+type GinkgoConfigType struct {
+	RandomSeed         int64
+	RandomizeAllSpecs  bool
+	RegexScansFilePath bool
+	FocusString        string
+	SkipString         string
+	SkipMeasurements   bool
+	FailOnPending      bool
+	FailFast           bool
+	FlakeAttempts      int
+	EmitSpecProgress   bool
+	DryRun             bool
+
+	ParallelNode  int
+	ParallelTotal int
+	SyncHost      string
+	StreamHost    string
+
+	// SyncTransport selects the mechanism used by parallel nodes to
+	// coordinate CompoundBeforeSuite state. Supported values are "http"
+	// (the default) and "grpc". There is no AfterSuite sync node yet, so
+	// this only affects CompoundBeforeSuite.
+	SyncTransport string
+
+	// SyncBackend, when set, overrides SyncTransport and synchronizes
+	// CompoundBeforeSuite state through an external coordination service
+	// rather than the built-in sync host. Supported values are ""
+	// (none, the default) and "etcd".
+	SyncBackend string
+	// SyncEndpoints is a comma-separated list of addresses for the service
+	// named by SyncBackend, e.g. a comma-separated list of etcd endpoints.
+	SyncEndpoints string
+
+	// The following control the retry/backoff schedule HTTPTransport uses
+	// while waiting for BeforeSuite state: connection refused during node 1
+	// startup, 5xx responses, and timeouts are treated as transient and
+	// attempted up to SyncRetryMaxAttempts times in all, sleeping
+	// min(SyncRetryMaxDelay, SyncRetryInitialDelay * SyncRetryMultiplier^n)
+	// +/- SyncRetryJitterFraction between attempts.
+	SyncRetryMaxAttempts    int
+	SyncRetryInitialDelay   time.Duration
+	SyncRetryMultiplier     float64
+	SyncRetryMaxDelay       time.Duration
+	SyncRetryJitterFraction float64
+
+	// The following configure TLS (and, when a client cert/key are given,
+	// mutual TLS) between parallel nodes and the BeforeSuite sync host.
+	// When SyncTLSCA or SyncTLSCert is set, HTTPTransport dials the sync host over
+	// https:// instead of http://.
+	SyncTLSCA      string
+	SyncTLSCert    string
+	SyncTLSKey     string
+	SyncServerName string
+	// SyncRequireClientCert, when the sync host is serving TLS, makes it
+	// require and verify a client certificate signed by SyncTLSCA before
+	// answering a node's request.
+	SyncRequireClientCert bool
+}
+
+var flagSet *flag.FlagSet
+
+func Flags(flagSet *flag.FlagSet, prefix string, includeParallelFlags bool) {
+	flagSet.Int64Var(&(GinkgoConfig.RandomSeed), prefix+"seed", time.Now().Unix(), "The seed used to randomize the spec suite.")
+	flagSet.BoolVar(&(GinkgoConfig.RandomizeAllSpecs), prefix+"randomizeAllSpecs", false, "If set, ginkgo will randomize all specs together.  By default, ginkgo only randomizes the top level Describe, Context and When containers.")
+	flagSet.BoolVar(&(GinkgoConfig.SkipMeasurements), prefix+"skipMeasurements", false, "If set, ginkgo will skip any measurement specs.")
+	flagSet.BoolVar(&(GinkgoConfig.FailOnPending), prefix+"failOnPending", false, "If set, ginkgo will mark the test suite as failed if any specs are pending.")
+	flagSet.BoolVar(&(GinkgoConfig.FailFast), prefix+"failFast", false, "If set, ginkgo will stop running a test suite after the first failed spec.")
+	flagSet.BoolVar(&(GinkgoConfig.DryRun), prefix+"dryRun", false, "If set, ginkgo will walk the test hierarchy without actually running anything.  Best paired with -v.")
+	flagSet.StringVar(&(GinkgoConfig.FocusString), prefix+"focus", "", "If set, ginkgo will only run specs that match this regular expression.")
+	flagSet.StringVar(&(GinkgoConfig.SkipString), prefix+"skip", "", "If set, ginkgo will only run specs that do not match this regular expression.")
+	flagSet.IntVar(&(GinkgoConfig.FlakeAttempts), prefix+"flakeAttempts", 1, "Make up to this many attempts to run each spec. Please note that if any of the attempts succeed, the suite will not be failed.")
+	flagSet.BoolVar(&(GinkgoConfig.EmitSpecProgress), prefix+"progress", false, "If set, ginkgo will emit progress information as each spec runs to the GinkgoWriter.")
+
+	if includeParallelFlags {
+		flagSet.IntVar(&(GinkgoConfig.ParallelNode), prefix+"parallel.node", 1, "This worker node's (one-indexed) node number.  For use with ginkgo's CLI to enable parallel testing.")
+		flagSet.IntVar(&(GinkgoConfig.ParallelTotal), prefix+"parallel.total", 1, "The total number of worker nodes.  For use with ginkgo's CLI to enable parallel testing.")
+		flagSet.StringVar(&(GinkgoConfig.SyncHost), prefix+"parallel.synchost", "", "The address for the server that will synchronize the BeforeSuite for parallel nodes.")
+		flagSet.StringVar(&(GinkgoConfig.StreamHost), prefix+"parallel.streamhost", "", "The address for the server that will receive streaming output from parallel nodes.")
+		flagSet.StringVar(&(GinkgoConfig.SyncTransport), prefix+"parallel.synctransport", "http", "The transport used to synchronize CompoundBeforeSuite state across parallel nodes. One of: http, grpc.")
+		flagSet.StringVar(&(GinkgoConfig.SyncBackend), prefix+"parallel.syncbackend", "", "An external coordination service to synchronize CompoundBeforeSuite state through instead of parallel.synchost. One of: \"\" (none), etcd.")
+		flagSet.StringVar(&(GinkgoConfig.SyncEndpoints), prefix+"parallel.syncendpoints", "", "A comma-separated list of addresses for the service named by parallel.syncbackend.")
+		flagSet.IntVar(&(GinkgoConfig.SyncRetryMaxAttempts), prefix+"parallel.syncretrymaxattempts", 10, "The maximum number of attempts (the first included) made through transient errors while waiting for BeforeSuite state before failing the suite.")
+		flagSet.DurationVar(&(GinkgoConfig.SyncRetryInitialDelay), prefix+"parallel.syncretryinitialdelay", 50*time.Millisecond, "The delay before the first retry of a transient sync error.")
+		flagSet.Float64Var(&(GinkgoConfig.SyncRetryMultiplier), prefix+"parallel.syncretrymultiplier", 2.0, "The multiplier applied to the retry delay after each transient sync error.")
+		flagSet.DurationVar(&(GinkgoConfig.SyncRetryMaxDelay), prefix+"parallel.syncretrymaxdelay", 5*time.Second, "The maximum delay between retries of a transient sync error.")
+		flagSet.Float64Var(&(GinkgoConfig.SyncRetryJitterFraction), prefix+"parallel.syncretryjitter", 0.2, "The fraction of the retry delay to randomly jitter by, to avoid a thundering herd of reconnecting nodes.")
+		flagSet.StringVar(&(GinkgoConfig.SyncTLSCA), prefix+"parallel.synctlsca", "", "A PEM-encoded CA certificate used to verify the sync host (and, with synctlscert/synctlskey, client connections to it).")
+		flagSet.StringVar(&(GinkgoConfig.SyncTLSCert), prefix+"parallel.synctlscert", "", "A PEM-encoded client certificate presented to the sync host for mutual TLS.")
+		flagSet.StringVar(&(GinkgoConfig.SyncTLSKey), prefix+"parallel.synctlskey", "", "The private key for synctlscert.")
+		flagSet.StringVar(&(GinkgoConfig.SyncServerName), prefix+"parallel.syncservername", "", "The server name to verify the sync host's certificate against, if it differs from the host in parallel.synchost.")
+		flagSet.BoolVar(&(GinkgoConfig.SyncRequireClientCert), prefix+"parallel.syncrequireclientcert", false, "If set, the sync host requires and verifies a client certificate (signed by parallel.synctlsca) from connecting nodes.")
+	}
+}
+
+var GinkgoConfig = GinkgoConfigType{}
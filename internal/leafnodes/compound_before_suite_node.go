@@ -1,14 +1,15 @@
 package leafnodes
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"github.com/onsi/ginkgo/internal/failer"
-	"github.com/onsi/ginkgo/types"
-	"io/ioutil"
-	"net/http"
+	"errors"
 	"reflect"
 	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/types"
 )
 
 type RemoteStateState int
@@ -38,7 +39,7 @@ type compoundBeforeSuiteNode struct {
 
 	ginkgoNode       int
 	totalGinkgoNodes int
-	syncHost         string
+	transport        SyncTransport
 
 	data []byte
 
@@ -48,10 +49,17 @@ type compoundBeforeSuiteNode struct {
 }
 
 func NewCompoundBeforeSuiteNode(bodyA interface{}, bodyB interface{}, codeLocation types.CodeLocation, timeout time.Duration, failer *failer.Failer, ginkgoNode int, totalGinkgoNodes int, syncHost string) SuiteNode {
+	return newCompoundBeforeSuiteNode(bodyA, bodyB, codeLocation, timeout, failer, ginkgoNode, totalGinkgoNodes, NewSyncTransport(config.GinkgoConfig, syncHost))
+}
+
+// newCompoundBeforeSuiteNode takes an explicit SyncTransport so callers (and
+// tests) can swap in the transport of their choosing instead of letting it
+// be inferred from config.GinkgoConfig.
+func newCompoundBeforeSuiteNode(bodyA interface{}, bodyB interface{}, codeLocation types.CodeLocation, timeout time.Duration, failer *failer.Failer, ginkgoNode int, totalGinkgoNodes int, transport SyncTransport) SuiteNode {
 	node := &compoundBeforeSuiteNode{
 		ginkgoNode:       ginkgoNode,
 		totalGinkgoNodes: totalGinkgoNodes,
-		syncHost:         syncHost,
+		transport:        transport,
 	}
 
 	node.runnerA = newRunner(node.wrapA(bodyA), codeLocation, timeout, failer, types.SpecComponentTypeBeforeSuite, 0)
@@ -60,86 +68,77 @@ func NewCompoundBeforeSuiteNode(bodyA interface{}, bodyB interface{}, codeLocati
 	return node
 }
 
-func (node *compoundBeforeSuiteNode) Run() bool {
+func (node *compoundBeforeSuiteNode) Run(ctx context.Context) bool {
 	t := time.Now()
 	defer func() {
 		node.runTime = time.Since(t)
 	}()
 
 	if node.ginkgoNode == 1 {
-		node.outcome, node.failure = node.runA()
+		node.outcome, node.failure = node.runA(ctx)
 	} else {
-		node.outcome, node.failure = node.waitForA()
+		node.outcome, node.failure = node.waitForA(ctx)
 	}
 
 	if node.outcome != types.SpecStatePassed {
 		return false
 	}
-	node.outcome, node.failure = node.runnerB.run()
+	node.outcome, node.failure = node.runnerB.run(ctx)
 
 	return node.outcome == types.SpecStatePassed
 }
 
-func (node *compoundBeforeSuiteNode) runA() (types.SpecState, types.SpecFailure) {
-	outcome, failure := node.runnerA.run()
+func (node *compoundBeforeSuiteNode) runA(ctx context.Context) (types.SpecState, types.SpecFailure) {
+	outcome, failure := node.runnerA.run(ctx)
 
 	if node.totalGinkgoNodes > 1 {
 		state := RemoteStateStatePassed
 		if outcome != types.SpecStatePassed {
 			state = RemoteStateStateFailed
 		}
-		json := (RemoteState{
+		node.transport.PublishBeforeSuiteState(ctx, RemoteState{
 			Data:  node.data,
 			State: state,
-		}).ToJSON()
-		http.Post(node.syncHost+"/BeforeSuiteState", "application/json", bytes.NewBuffer(json))
+		})
 	}
 
 	return outcome, failure
 }
 
-func (node *compoundBeforeSuiteNode) waitForA() (types.SpecState, types.SpecFailure) {
-	failure := func(message string) types.SpecFailure {
+func (node *compoundBeforeSuiteNode) waitForA(ctx context.Context) (types.SpecState, types.SpecFailure) {
+	failure := func(cause *SyncError) types.SpecFailure {
 		return types.SpecFailure{
-			Message:               message,
+			Message:               cause.Error(),
 			Location:              node.runnerA.codeLocation,
 			ComponentType:         node.runnerA.nodeType,
 			ComponentIndex:        node.runnerA.componentIndex,
 			ComponentCodeLocation: node.runnerA.codeLocation,
+			Cause:                 cause,
 		}
 	}
-	for {
-		resp, err := http.Get(node.syncHost + "/BeforeSuiteState")
-		if err != nil || resp.StatusCode != http.StatusOK {
-			return types.SpecStateFailed, failure("Failed to fetch BeforeSuite state")
-		}
-
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return types.SpecStateFailed, failure("Failed to read BeforeSuite state")
-		}
-		resp.Body.Close()
 
-		r := RemoteState{}
-		err = json.Unmarshal(body, &r)
-		if err != nil {
-			return types.SpecStateFailed, failure("Failed to decode BeforeSuite state")
+	r, err := node.transport.AwaitBeforeSuiteState(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return types.SpecStateInterrupted, failure(&SyncError{Code: SyncErrTimeout, Err: ctx.Err()})
 		}
-
-		switch r.State {
-		case RemoteStateStatePassed:
-			node.data = r.Data
-			return types.SpecStatePassed, types.SpecFailure{}
-		case RemoteStateStateFailed:
-			return types.SpecStateFailed, failure("BeforeSuite on Node 1 failed")
-		case RemoteStateStateDisappeared:
-			return types.SpecStateFailed, failure("Node 1 dissappeared before completing BeforeSuite")
+		if syncErr, ok := err.(*SyncError); ok {
+			return types.SpecStateFailed, failure(syncErr)
 		}
+		return types.SpecStateFailed, failure(&SyncError{Code: SyncErrTransport, Err: err})
+	}
 
-		time.Sleep(50 * time.Millisecond)
+	switch r.State {
+	case RemoteStateStatePassed:
+		node.data = r.Data
+		return types.SpecStatePassed, types.SpecFailure{}
+	case RemoteStateStateFailed:
+		return types.SpecStateFailed, failure(&SyncError{Code: SyncErrNode1Failed, Err: errors.New("BeforeSuite on Node 1 failed")})
+	case RemoteStateStateDisappeared:
+		return types.SpecStateFailed, failure(&SyncError{Code: SyncErrNode1Disappeared, Err: errors.New("Node 1 dissappeared before completing BeforeSuite")})
 	}
 
-	return types.SpecStateFailed, failure("Shouldn't get here!")
+	return types.SpecStateFailed, failure(&SyncError{Code: SyncErrTransport, Err: errors.New("sync transport returned an unrecognized RemoteStateState")})
 }
 
 func (node *compoundBeforeSuiteNode) Passed() bool {
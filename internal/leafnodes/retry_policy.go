@@ -0,0 +1,74 @@
+package leafnodes
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+)
+
+// RetryPolicy governs how HTTPTransport retries a transient error (a
+// connection refused while node 1 is still starting up, a 5xx response, a
+// timeout) while waiting for BeforeSuite state. It also sets the poll
+// interval: every RemoteStateStatePending response resets the backoff, so
+// successive Pending polls are each spaced by the (jittered) InitialDelay,
+// while consecutive errors back off further.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialDelay   time.Duration
+	Multiplier     float64
+	MaxDelay       time.Duration
+	JitterFraction float64
+}
+
+// DefaultRetryPolicy mirrors the defaults config.Flags registers for the
+// SyncRetry* settings, so HTTPTransport behaves sensibly even when it's constructed
+// outside of the usual config.Flags-driven startup path.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    10,
+	InitialDelay:   50 * time.Millisecond,
+	Multiplier:     2.0,
+	MaxDelay:       5 * time.Second,
+	JitterFraction: 0.2,
+}
+
+func RetryPolicyFromConfig(conf config.GinkgoConfigType) RetryPolicy {
+	policy := DefaultRetryPolicy
+	if conf.SyncRetryMaxAttempts > 0 {
+		policy.MaxAttempts = conf.SyncRetryMaxAttempts
+	}
+	if conf.SyncRetryInitialDelay > 0 {
+		policy.InitialDelay = conf.SyncRetryInitialDelay
+	}
+	if conf.SyncRetryMultiplier > 0 {
+		policy.Multiplier = conf.SyncRetryMultiplier
+	}
+	if conf.SyncRetryMaxDelay > 0 {
+		policy.MaxDelay = conf.SyncRetryMaxDelay
+	}
+	if conf.SyncRetryJitterFraction > 0 {
+		policy.JitterFraction = conf.SyncRetryJitterFraction
+	}
+	return policy
+}
+
+// delay returns the sleep duration before retry attempt n (0-indexed),
+// jittered by +/- JitterFraction.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(attempt))
+	if max := float64(p.MaxDelay); max > 0 && d > max {
+		d = max
+	}
+
+	if p.JitterFraction > 0 {
+		jitter := d * p.JitterFraction
+		d += jitter*2*rand.Float64() - jitter
+	}
+
+	if d < 0 {
+		d = 0
+	}
+
+	return time.Duration(d)
+}
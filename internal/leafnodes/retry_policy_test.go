@@ -0,0 +1,66 @@
+package leafnodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    10,
+		InitialDelay:   100 * time.Millisecond,
+		Multiplier:     2.0,
+		MaxDelay:       1 * time.Second,
+		JitterFraction: 0,
+	}
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: 100 * time.Millisecond},
+		{attempt: 1, want: 200 * time.Millisecond},
+		{attempt: 2, want: 400 * time.Millisecond},
+		{attempt: 3, want: 800 * time.Millisecond},
+		{attempt: 4, want: 1 * time.Second}, // clamped to MaxDelay
+		{attempt: 10, want: 1 * time.Second},
+	}
+
+	for _, c := range cases {
+		if got := policy.delay(c.attempt); got != c.want {
+			t.Errorf("delay(%d) = %s, want %s", c.attempt, got, c.want)
+		}
+	}
+}
+
+func TestRetryPolicyDelayJitterStaysWithinFraction(t *testing.T) {
+	policy := RetryPolicy{
+		InitialDelay:   100 * time.Millisecond,
+		Multiplier:     2.0,
+		MaxDelay:       1 * time.Second,
+		JitterFraction: 0.2,
+	}
+
+	base := 200 * time.Millisecond // attempt 1, pre-jitter
+	low := base - base/5
+	high := base + base/5
+
+	for i := 0; i < 50; i++ {
+		d := policy.delay(1)
+		if d < low || d > high {
+			t.Fatalf("delay(1) = %s, want within [%s, %s]", d, low, high)
+		}
+	}
+}
+
+func TestRetryPolicyFromConfig(t *testing.T) {
+	got := RetryPolicyFromConfig(config.GinkgoConfigType{SyncRetryMaxAttempts: 3})
+	if got.MaxAttempts != 3 {
+		t.Errorf("MaxAttempts = %d, want 3", got.MaxAttempts)
+	}
+	if got.InitialDelay != DefaultRetryPolicy.InitialDelay {
+		t.Errorf("InitialDelay = %s, want default %s unchanged", got.InitialDelay, DefaultRetryPolicy.InitialDelay)
+	}
+}
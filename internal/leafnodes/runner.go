@@ -0,0 +1,107 @@
+package leafnodes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/types"
+)
+
+type runner struct {
+	isAsync          bool
+	asyncFunc        func(chan<- interface{})
+	syncFunc         func()
+	codeLocation     types.CodeLocation
+	timeoutThreshold time.Duration
+	nodeType         types.SpecComponentType
+	componentIndex   int
+	failer           *failer.Failer
+}
+
+func newRunner(body interface{}, codeLocation types.CodeLocation, timeout time.Duration, failer *failer.Failer, nodeType types.SpecComponentType, componentIndex int) *runner {
+	r := &runner{
+		codeLocation:     codeLocation,
+		timeoutThreshold: timeout,
+		nodeType:         nodeType,
+		componentIndex:   componentIndex,
+		failer:           failer,
+	}
+
+	switch fn := body.(type) {
+	case func(chan<- interface{}):
+		r.isAsync = true
+		r.asyncFunc = fn
+	case func():
+		r.syncFunc = fn
+	default:
+		panic(fmt.Sprintf("unsupported runner body type %s", reflect.TypeOf(body)))
+	}
+
+	return r
+}
+
+// run executes the node body, honoring ctx for both an overall deadline
+// (derived from r.timeoutThreshold, when set) and external cancellation —
+// Ctrl-C, the outer suite's deadline, or a failing sibling node.
+func (r *runner) run(ctx context.Context) (outcome types.SpecState, failure types.SpecFailure) {
+	if r.timeoutThreshold > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeoutThreshold)
+		defer cancel()
+	}
+
+	done := make(chan interface{}, 1)
+
+	go func() {
+		defer func() {
+			if e := recover(); e != nil {
+				r.failer.Panic(r.codeLocation, e)
+			}
+			done <- nil
+		}()
+
+		if r.isAsync {
+			innerDone := make(chan interface{})
+			innerPanic := make(chan interface{}, 1)
+			go func() {
+				defer func() {
+					if e := recover(); e != nil {
+						innerPanic <- e
+					}
+				}()
+				r.asyncFunc(innerDone)
+			}()
+			select {
+			case <-innerDone:
+			case e := <-innerPanic:
+				panic(e)
+			case <-ctx.Done():
+				// Record the timeout/interruption before done is signalled,
+				// so the outer select can't report this as a pass.
+				if ctx.Err() == context.DeadlineExceeded {
+					r.failer.Timeout(r.codeLocation)
+				} else {
+					r.failer.Interrupted(r.codeLocation)
+				}
+				return
+			}
+		} else {
+			r.syncFunc()
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			r.failer.Timeout(r.codeLocation)
+		} else {
+			r.failer.Interrupted(r.codeLocation)
+		}
+	}
+
+	return r.failer.Drain(r.nodeType, r.componentIndex, r.codeLocation)
+}
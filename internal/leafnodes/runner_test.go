@@ -0,0 +1,37 @@
+package leafnodes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/types"
+)
+
+// TestRunnerRecoversPanicFromAsyncBody guards against a panic in the
+// goroutine that calls asyncFunc directly (as opposed to the outer
+// goroutine that spawns it) escaping recover() and crashing the process.
+func TestRunnerRecoversPanicFromAsyncBody(t *testing.T) {
+	f := failer.New()
+	r := newRunner(func(done chan<- interface{}) {
+		panic("boom")
+	}, types.CodeLocation{}, 0, f, types.SpecComponentTypeBeforeSuite, 0)
+
+	done := make(chan struct{})
+	var outcome types.SpecState
+	go func() {
+		outcome, _ = r.run(context.Background())
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("runner.run did not return - panic likely escaped recover and crashed the goroutine")
+	}
+
+	if outcome != types.SpecStatePanicked {
+		t.Errorf("outcome = %v, want SpecStatePanicked", outcome)
+	}
+}
@@ -0,0 +1,17 @@
+package leafnodes
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/types"
+)
+
+// SuiteNode is implemented by the BeforeSuite/AfterSuite family of nodes.
+// Run takes a context so the node can be aborted — by Ctrl-C, the outer
+// suite's deadline, or a failing sibling node — instead of running (or
+// waiting) unconditionally to completion.
+type SuiteNode interface {
+	Run(ctx context.Context) bool
+	Passed() bool
+	Summary() *types.SetupSummary
+}
@@ -0,0 +1,109 @@
+package leafnodes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrSyncStreamClosed is returned by GRPCTransport when the server closes
+// the Watch stream before a terminal state was ever observed.
+var ErrSyncStreamClosed = errors.New("sync transport stream closed before a terminal BeforeSuite state was observed")
+
+// SyncErrCode identifies why waitForA failed to obtain a passing BeforeSuite
+// state, so callers (CI systems, reporters) can distinguish these cases
+// without regex-parsing a free-form message.
+type SyncErrCode int
+
+const (
+	SyncErrCodeInvalid SyncErrCode = iota
+
+	// SyncErrTransport means the transport itself failed - a dial/connect
+	// error, a timeout, a non-200 response - after exhausting any retries.
+	SyncErrTransport
+	// SyncErrDecode means a response was received but couldn't be decoded
+	// into a RemoteState.
+	SyncErrDecode
+	// SyncErrNode1Failed means node 1 reported RemoteStateStateFailed.
+	SyncErrNode1Failed
+	// SyncErrNode1Disappeared means node 1's state expired or was deleted
+	// (e.g. an etcd lease expiring) before it reported a terminal state.
+	SyncErrNode1Disappeared
+	// SyncErrTimeout means the wait was aborted by a context deadline or
+	// cancellation before a terminal state was observed.
+	SyncErrTimeout
+)
+
+func (c SyncErrCode) String() string {
+	switch c {
+	case SyncErrTransport:
+		return "SyncErrTransport"
+	case SyncErrDecode:
+		return "SyncErrDecode"
+	case SyncErrNode1Failed:
+		return "SyncErrNode1Failed"
+	case SyncErrNode1Disappeared:
+		return "SyncErrNode1Disappeared"
+	case SyncErrTimeout:
+		return "SyncErrTimeout"
+	default:
+		return "SyncErrInvalid"
+	}
+}
+
+// SyncError is the structured failure attached to types.SpecFailure.Cause
+// when waitForA fails. It carries enough of the underlying transport error
+// to let a reporter or CI system tell "node 1 crashed" apart from "sync
+// host unreachable" programmatically.
+type SyncError struct {
+	Code SyncErrCode
+	Err  error
+
+	// StatusCode is the HTTP status code that produced this error, when
+	// applicable (zero otherwise).
+	StatusCode int
+	// Attempts is the number of attempts made before giving up, when
+	// applicable (zero otherwise).
+	Attempts int
+}
+
+func (e *SyncError) Error() string {
+	msg := fmt.Sprintf("[%s]", e.Code)
+	if e.Err != nil {
+		msg += " " + e.Err.Error()
+	}
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (status %d)", e.StatusCode)
+	}
+	if e.Attempts != 0 {
+		msg += fmt.Sprintf(" (after %d attempts)", e.Attempts)
+	}
+	return msg
+}
+
+func (e *SyncError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON lets a JSON reporter render the structured code/status/attempt
+// fields of a SyncError stored in types.SpecFailure.Cause, rather than
+// the default encoding, which renders Code as a bare number and Err as an
+// empty object.
+func (e *SyncError) MarshalJSON() ([]byte, error) {
+	message := ""
+	if e.Err != nil {
+		message = e.Err.Error()
+	}
+
+	return json.Marshal(struct {
+		Code       string `json:"code"`
+		Message    string `json:"message"`
+		StatusCode int    `json:"statusCode,omitempty"`
+		Attempts   int    `json:"attempts,omitempty"`
+	}{
+		Code:       e.Code.String(),
+		Message:    message,
+		StatusCode: e.StatusCode,
+		Attempts:   e.Attempts,
+	})
+}
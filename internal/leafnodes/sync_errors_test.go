@@ -0,0 +1,95 @@
+package leafnodes
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestSyncErrorError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  *SyncError
+		want string
+	}{
+		{
+			name: "bare code, no underlying error",
+			err:  &SyncError{Code: SyncErrTimeout},
+			want: "[SyncErrTimeout]",
+		},
+		{
+			name: "wraps an underlying error",
+			err:  &SyncError{Code: SyncErrTransport, Err: errors.New("connection refused")},
+			want: "[SyncErrTransport] connection refused",
+		},
+		{
+			name: "includes status code",
+			err:  &SyncError{Code: SyncErrTransport, Err: errors.New("bad response"), StatusCode: 503},
+			want: "[SyncErrTransport] bad response (status 503)",
+		},
+		{
+			name: "includes attempts",
+			err:  &SyncError{Code: SyncErrTransport, Err: errors.New("timed out"), Attempts: 5},
+			want: "[SyncErrTransport] timed out (after 5 attempts)",
+		},
+		{
+			name: "includes status code and attempts together",
+			err:  &SyncError{Code: SyncErrTransport, Err: errors.New("bad response"), StatusCode: 503, Attempts: 5},
+			want: "[SyncErrTransport] bad response (status 503) (after 5 attempts)",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.err.Error(); got != c.want {
+				t.Errorf("Error() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSyncErrorUnwrap(t *testing.T) {
+	underlying := errors.New("boom")
+	err := &SyncError{Code: SyncErrDecode, Err: underlying}
+
+	if !errors.Is(err, underlying) {
+		t.Errorf("errors.Is(err, underlying) = false, want true")
+	}
+}
+
+func TestSyncErrorMarshalJSON(t *testing.T) {
+	err := &SyncError{
+		Code:       SyncErrNode1Disappeared,
+		Err:        errors.New("lease expired"),
+		StatusCode: 0,
+		Attempts:   3,
+	}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("json.Marshal returned an error: %s", marshalErr)
+	}
+
+	var decoded struct {
+		Code       string `json:"code"`
+		Message    string `json:"message"`
+		StatusCode int    `json:"statusCode"`
+		Attempts   int    `json:"attempts"`
+	}
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		t.Fatalf("json.Unmarshal returned an error: %s", unmarshalErr)
+	}
+
+	if decoded.Code != "SyncErrNode1Disappeared" {
+		t.Errorf("code = %q, want %q", decoded.Code, "SyncErrNode1Disappeared")
+	}
+	if decoded.Message != "lease expired" {
+		t.Errorf("message = %q, want %q", decoded.Message, "lease expired")
+	}
+	if decoded.StatusCode != 0 {
+		t.Errorf("statusCode = %d, want omitted (0)", decoded.StatusCode)
+	}
+	if decoded.Attempts != 3 {
+		t.Errorf("attempts = %d, want 3", decoded.Attempts)
+	}
+}
@@ -0,0 +1,46 @@
+package leafnodes
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/onsi/ginkgo/config"
+)
+
+// buildClientTLSConfig turns conf's sync TLS settings into a *tls.Config
+// suitable for an http.Transport talking to the sync host. SyncTLSCA is
+// loaded into RootCAs so the sync host's certificate can be verified;
+// SyncTLSCert/SyncTLSKey, when both present, are loaded into Certificates
+// so the sync host can in turn verify this node (mutual TLS).
+func buildClientTLSConfig(conf config.GinkgoConfigType) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: conf.SyncServerName,
+	}
+
+	if conf.SyncTLSCA != "" {
+		pem, err := ioutil.ReadFile(conf.SyncTLSCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sync TLS CA %s: %s", conf.SyncTLSCA, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse sync TLS CA %s", conf.SyncTLSCA)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if conf.SyncTLSCert != "" {
+		if conf.SyncTLSKey == "" {
+			return nil, fmt.Errorf("synctlscert was set without a corresponding synctlskey")
+		}
+		cert, err := tls.LoadX509KeyPair(conf.SyncTLSCert, conf.SyncTLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load sync TLS client cert/key: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,194 @@
+package leafnodes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+)
+
+// SyncTransport is implemented by anything that can publish and await
+// CompoundBeforeSuite state across parallel Ginkgo nodes. Node 1 calls
+// PublishBeforeSuiteState once its half of the suite setup has run; every
+// other node calls AwaitBeforeSuiteState and blocks until node 1's state is
+// available (or the context is cancelled). There is no AfterSuite sync node
+// in this package yet, so SyncTransport is only wired into
+// NewCompoundBeforeSuiteNode.
+type SyncTransport interface {
+	PublishBeforeSuiteState(ctx context.Context, state RemoteState) error
+	AwaitBeforeSuiteState(ctx context.Context) (RemoteState, error)
+}
+
+// NewSyncTransport constructs the SyncTransport selected by conf. It panics
+// if conf.SyncTransport names an unsupported transport, mirroring the way
+// other unrecognized-flag errors surface during Ginkgo startup.
+func NewSyncTransport(conf config.GinkgoConfigType, syncHost string) SyncTransport {
+	switch conf.SyncBackend {
+	case "":
+	case "etcd":
+		transport, err := newEtcdTransportFromConfig(conf)
+		if err != nil {
+			panic(fmt.Sprintf("failed to configure TLS for etcd sync backend: %s", err))
+		}
+		return transport
+	default:
+		panic(fmt.Sprintf("unknown sync backend %q", conf.SyncBackend))
+	}
+
+	switch conf.SyncTransport {
+	case "", "http":
+		return newHTTPTransportFromConfig(conf, syncHost)
+	case "grpc":
+		transport, err := newGRPCTransportFromConfig(conf, syncHost)
+		if err != nil {
+			panic(fmt.Sprintf("failed to configure TLS for gRPC sync transport: %s", err))
+		}
+		return transport
+	default:
+		panic(fmt.Sprintf("unknown sync transport %q", conf.SyncTransport))
+	}
+}
+
+// newHTTPTransportFromConfig builds an HTTPTransport whose client is
+// configured for TLS (and, when a client cert/key are present, mutual TLS)
+// when conf asks for it, rewriting syncHost to https:// in that case.
+func newHTTPTransportFromConfig(conf config.GinkgoConfigType, syncHost string) *HTTPTransport {
+	transport := NewHTTPTransportWithRetryPolicy(syncHost, RetryPolicyFromConfig(conf))
+
+	if conf.SyncTLSCA == "" && conf.SyncTLSCert == "" {
+		return transport
+	}
+
+	tlsConfig, err := buildClientTLSConfig(conf)
+	if err != nil {
+		panic(fmt.Sprintf("failed to configure TLS for sync host: %s", err))
+	}
+
+	transport.client = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	if !strings.HasPrefix(transport.syncHost, "https://") {
+		transport.syncHost = "https://" + strings.TrimPrefix(transport.syncHost, "http://")
+	}
+
+	return transport
+}
+
+// HTTPTransport is the original SyncTransport: node 1 POSTs its state to a
+// known syncHost and every other node polls that same host with GET until a
+// terminal state shows up. Transient errors (node 1 not up yet, a 5xx, a
+// timeout) are retried with backoff rather than failing the suite outright.
+type HTTPTransport struct {
+	syncHost    string
+	client      *http.Client
+	retryPolicy RetryPolicy
+}
+
+func NewHTTPTransport(syncHost string) *HTTPTransport {
+	return NewHTTPTransportWithRetryPolicy(syncHost, DefaultRetryPolicy)
+}
+
+func NewHTTPTransportWithRetryPolicy(syncHost string, retryPolicy RetryPolicy) *HTTPTransport {
+	return &HTTPTransport{
+		syncHost:    syncHost,
+		client:      http.DefaultClient,
+		retryPolicy: retryPolicy,
+	}
+}
+
+func (t *HTTPTransport) PublishBeforeSuiteState(ctx context.Context, state RemoteState) error {
+	req, err := http.NewRequest("POST", t.syncHost+"/BeforeSuiteState", bytes.NewBuffer(state.ToJSON()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (t *HTTPTransport) AwaitBeforeSuiteState(ctx context.Context) (RemoteState, error) {
+	attempt := 0
+	for {
+		state, ok, err := t.fetch(ctx)
+		if err != nil {
+			attempt++
+			if attempt >= t.retryPolicy.MaxAttempts {
+				if syncErr, ok := err.(*SyncError); ok {
+					syncErr.Attempts = attempt
+					return RemoteState{}, syncErr
+				}
+				return RemoteState{}, &SyncError{Code: SyncErrTransport, Err: err, Attempts: attempt}
+			}
+			if waitErr := t.sleep(ctx, t.retryPolicy.delay(attempt-1)); waitErr != nil {
+				return RemoteState{}, waitErr
+			}
+			continue
+		}
+
+		if ok {
+			return state, nil
+		}
+
+		// A valid RemoteStateStatePending response means node 1 simply
+		// hasn't finished yet; reset the backoff and keep polling on the
+		// same schedule used for transient errors.
+		attempt = 0
+		if waitErr := t.sleep(ctx, t.retryPolicy.delay(0)); waitErr != nil {
+			return RemoteState{}, waitErr
+		}
+	}
+}
+
+func (t *HTTPTransport) sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// fetch makes a single attempt to read the BeforeSuite state.  It returns
+// ok == false when the state is still pending and polling should continue.
+func (t *HTTPTransport) fetch(ctx context.Context) (state RemoteState, ok bool, err error) {
+	req, err := http.NewRequest("GET", t.syncHost+"/BeforeSuiteState", nil)
+	if err != nil {
+		return RemoteState{}, false, &SyncError{Code: SyncErrTransport, Err: err}
+	}
+	resp, err := t.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return RemoteState{}, false, &SyncError{Code: SyncErrTransport, Err: err}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return RemoteState{}, false, &SyncError{
+			Code:       SyncErrTransport,
+			Err:        fmt.Errorf("unexpected status code from %s", t.syncHost),
+			StatusCode: resp.StatusCode,
+		}
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return RemoteState{}, false, &SyncError{Code: SyncErrTransport, Err: err}
+	}
+
+	r := RemoteState{}
+	if err := json.Unmarshal(body, &r); err != nil {
+		return RemoteState{}, false, &SyncError{Code: SyncErrDecode, Err: err}
+	}
+
+	if r.State == RemoteStateStatePending || r.State == RemoteStateStateInvalid {
+		return RemoteState{}, false, nil
+	}
+
+	return r, true, nil
+}
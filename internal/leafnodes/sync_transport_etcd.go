@@ -0,0 +1,159 @@
+package leafnodes
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/etcd/clientv3"
+
+	"github.com/onsi/ginkgo/config"
+)
+
+// etcdLeaseTTLSeconds is kept short because PublishBeforeSuiteState
+// actively renews the lease with KeepAlive for as long as node 1's process
+// is alive; the TTL only matters as the crash-detection fallback once
+// KeepAlive stops (the process died), so a short TTL here means a crashed
+// node 1 is detected quickly rather than leaving waiters blocked.
+const etcdLeaseTTLSeconds = 10
+
+// EtcdTransport is a SyncTransport backed by etcd, letting parallel Ginkgo
+// nodes spread across machines coordinate CompoundBeforeSuite state without
+// standing up the built-in HTTP sync host. It is structured behind the same
+// SyncTransport interface as HTTPTransport and GRPCTransport so other
+// coordination services (Consul, Redis, ...) can be added the same way.
+type EtcdTransport struct {
+	key       string
+	client    *clientv3.Client
+	endpoints []string
+	tlsConfig *tls.Config
+}
+
+// NewEtcdTransport scopes its key to runID (e.g. config.GinkgoConfigType's
+// RandomSeed, which is already shared across a run's parallel nodes for
+// exactly this reason) so that two suite runs sharing an etcd cluster don't
+// collide on the same BeforeSuite state.
+func NewEtcdTransport(endpoints []string, runID int64) *EtcdTransport {
+	return &EtcdTransport{
+		key:       fmt.Sprintf("/ginkgo/%d/beforesuite", runID),
+		endpoints: endpoints,
+	}
+}
+
+// newEtcdTransportFromConfig builds an EtcdTransport that connects to the
+// etcd cluster over TLS (and, when a client cert/key are present, mutual
+// TLS) when conf asks for it - the same prerequisite TLS configuration
+// HTTPTransport and GRPCTransport use, since an etcd cluster reachable
+// across untrusted networks needs exactly the same protection.
+func newEtcdTransportFromConfig(conf config.GinkgoConfigType) (*EtcdTransport, error) {
+	transport := NewEtcdTransport(strings.Split(conf.SyncEndpoints, ","), conf.RandomSeed)
+
+	if conf.SyncTLSCA == "" && conf.SyncTLSCert == "" {
+		return transport, nil
+	}
+
+	tlsConfig, err := buildClientTLSConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	transport.tlsConfig = tlsConfig
+	return transport, nil
+}
+
+func (t *EtcdTransport) connect() (*clientv3.Client, error) {
+	if t.client != nil {
+		return t.client, nil
+	}
+	client, err := clientv3.New(clientv3.Config{Endpoints: t.endpoints, TLS: t.tlsConfig})
+	if err != nil {
+		return nil, err
+	}
+	t.client = client
+	return client, nil
+}
+
+// PublishBeforeSuiteState writes the marshaled state under t.key with a
+// lease kept alive for as long as node 1's process is running. If node 1
+// crashes, nothing is left to keep renewing the lease, so it expires and
+// waiters watching t.key see a delete, which AwaitBeforeSuiteState reports
+// as RemoteStateStateDisappeared.
+func (t *EtcdTransport) PublishBeforeSuiteState(ctx context.Context, state RemoteState) error {
+	client, err := t.connect()
+	if err != nil {
+		return err
+	}
+
+	lease, err := client.Grant(ctx, etcdLeaseTTLSeconds)
+	if err != nil {
+		return err
+	}
+
+	// Deliberately keep this alive with a context independent of ctx (which
+	// is scoped to the BeforeSuite run and may well be done by the time node
+	// 1 reaches runnerB): the lease should stay renewed for node 1's entire
+	// process lifetime, and stop - letting the key expire - only when that
+	// process dies and the client connection goes with it.
+	keepAlive, err := client.KeepAlive(context.Background(), lease.ID)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for range keepAlive {
+		}
+	}()
+
+	_, err = client.Put(ctx, t.key, string(state.ToJSON()), clientv3.WithLease(lease.ID))
+	return err
+}
+
+// AwaitBeforeSuiteState watches t.key and returns as soon as a value is
+// written, rather than polling. A prior value is honored immediately so a
+// late-subscribing node doesn't miss state published before it started
+// watching. The watch is started from the revision right after the Get,
+// via clientv3.WithRev, so a Put landing between the Get returning empty
+// and the Watch being established isn't missed.
+func (t *EtcdTransport) AwaitBeforeSuiteState(ctx context.Context) (RemoteState, error) {
+	client, err := t.connect()
+	if err != nil {
+		return RemoteState{}, err
+	}
+
+	getResp, err := client.Get(ctx, t.key)
+	if err != nil {
+		return RemoteState{}, err
+	}
+	if len(getResp.Kvs) > 0 {
+		return decodeEtcdState(getResp.Kvs[0].Value)
+	}
+
+	watch := client.Watch(ctx, t.key, clientv3.WithRev(getResp.Header.Revision+1))
+	for {
+		select {
+		case <-ctx.Done():
+			return RemoteState{}, ctx.Err()
+		case resp, ok := <-watch:
+			if !ok {
+				return RemoteState{}, fmt.Errorf("etcd watch on %s closed", t.key)
+			}
+			for _, event := range resp.Events {
+				switch event.Type {
+				case clientv3.EventTypePut:
+					return decodeEtcdState(event.Kv.Value)
+				case clientv3.EventTypeDelete:
+					return RemoteState{State: RemoteStateStateDisappeared}, nil
+				}
+			}
+		}
+	}
+}
+
+func decodeEtcdState(data []byte) (RemoteState, error) {
+	r := RemoteState{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return RemoteState{}, &SyncError{Code: SyncErrDecode, Err: err}
+	}
+	return r, nil
+}
@@ -0,0 +1,112 @@
+package leafnodes
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/syncpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// GRPCTransport is a SyncTransport backed by a streaming gRPC service. Node 1
+// publishes its state once via a unary call; every other node opens a
+// server-streamed Watch and blocks on the stream instead of polling, so
+// published state changes are delivered as soon as they happen. Nothing on
+// this transport reports node 1 disappearing: a watcher waits until a
+// terminal state is published, the stream closes, or ctx is done.
+type GRPCTransport struct {
+	syncHost   string
+	dialOption grpc.DialOption
+}
+
+func NewGRPCTransport(syncHost string) *GRPCTransport {
+	return &GRPCTransport{syncHost: grpcTarget(syncHost), dialOption: grpc.WithInsecure()}
+}
+
+// grpcTarget strips the scheme SyncHost is otherwise expected to carry (see
+// HTTPTransport, which uses it as a URL prefix and at most rewrites
+// "http://" to "https://"):
+// grpc.DialContext dials a bare host:port and never connects when handed a
+// scheme-prefixed target, so GRPCTransport has to undo that convention
+// rather than share it.
+func grpcTarget(syncHost string) string {
+	syncHost = strings.TrimPrefix(syncHost, "https://")
+	syncHost = strings.TrimPrefix(syncHost, "http://")
+	return syncHost
+}
+
+// newGRPCTransportFromConfig builds a GRPCTransport that dials over TLS
+// (and, when a client cert/key are present, mutual TLS) when conf asks for
+// it - this is the same prerequisite TLS configuration HTTPTransport uses,
+// since running across untrusted networks is exactly what GRPCTransport is
+// for.
+func newGRPCTransportFromConfig(conf config.GinkgoConfigType, syncHost string) (*GRPCTransport, error) {
+	if conf.SyncTLSCA == "" && conf.SyncTLSCert == "" {
+		return NewGRPCTransport(syncHost), nil
+	}
+
+	tlsConfig, err := buildClientTLSConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GRPCTransport{
+		syncHost:   grpcTarget(syncHost),
+		dialOption: grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+	}, nil
+}
+
+func (t *GRPCTransport) dial(ctx context.Context) (*grpc.ClientConn, syncpb.BeforeSuiteSyncClient, error) {
+	conn, err := grpc.DialContext(ctx, t.syncHost, t.dialOption, grpc.WithBlock())
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, syncpb.NewBeforeSuiteSyncClient(conn), nil
+}
+
+func (t *GRPCTransport) PublishBeforeSuiteState(ctx context.Context, state RemoteState) error {
+	conn, client, err := t.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = client.Publish(ctx, &syncpb.BeforeSuiteState{
+		Data:  state.Data,
+		State: int32(state.State),
+	})
+	return err
+}
+
+func (t *GRPCTransport) AwaitBeforeSuiteState(ctx context.Context) (RemoteState, error) {
+	conn, client, err := t.dial(ctx)
+	if err != nil {
+		return RemoteState{}, err
+	}
+	defer conn.Close()
+
+	stream, err := client.Watch(ctx, &syncpb.BeforeSuiteStateRequest{})
+	if err != nil {
+		return RemoteState{}, err
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return RemoteState{}, ErrSyncStreamClosed
+		}
+		if err != nil {
+			return RemoteState{}, err
+		}
+
+		state := RemoteStateState(msg.State)
+		if state == RemoteStateStatePending || state == RemoteStateStateInvalid {
+			continue
+		}
+
+		return RemoteState{Data: msg.Data, State: state}, nil
+	}
+}
@@ -0,0 +1,51 @@
+package leafnodes_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/internal/leafnodes"
+	"github.com/onsi/ginkgo/internal/remote"
+)
+
+// TestGRPCTransportDialsThroughSyncHostConvention guards against
+// GRPCTransport.dial being handed the same http://-prefixed value
+// HTTPTransport uses for SyncHost - grpc.DialContext never connects to a
+// scheme-prefixed target, so this exercises the actual dial path end to
+// end rather than just the struct literals.
+func TestGRPCTransportDialsThroughSyncHostConvention(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %s", err)
+	}
+
+	server := remote.NewGRPCServer()
+	go remote.ServeGRPC(listener, server)
+
+	syncHost := "http://" + listener.Addr().String()
+	transport := leafnodes.NewGRPCTransport(syncHost)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := transport.PublishBeforeSuiteState(ctx, leafnodes.RemoteState{
+		Data:  []byte("hello"),
+		State: leafnodes.RemoteStateStatePassed,
+	}); err != nil {
+		t.Fatalf("PublishBeforeSuiteState returned an error: %s", err)
+	}
+
+	state, err := transport.AwaitBeforeSuiteState(ctx)
+	if err != nil {
+		t.Fatalf("AwaitBeforeSuiteState returned an error: %s", err)
+	}
+
+	if state.State != leafnodes.RemoteStateStatePassed {
+		t.Errorf("State = %v, want RemoteStateStatePassed", state.State)
+	}
+	if string(state.Data) != "hello" {
+		t.Errorf("Data = %q, want %q", state.Data, "hello")
+	}
+}
@@ -0,0 +1,80 @@
+package remote
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/onsi/ginkgo/internal/syncpb"
+	"google.golang.org/grpc"
+)
+
+// GRPCServer implements syncpb.BeforeSuiteSyncServer, the server-side
+// counterpart to leafnodes.GRPCTransport: node 1 calls Publish once, and
+// every node watching via Watch is pushed that state (and the current state
+// immediately upon subscribing, so a late watcher doesn't miss it) instead
+// of polling.
+type GRPCServer struct {
+	lock     *sync.Mutex
+	state    *syncpb.BeforeSuiteState
+	watchers map[chan *syncpb.BeforeSuiteState]bool
+}
+
+func NewGRPCServer() *GRPCServer {
+	return &GRPCServer{
+		lock:     &sync.Mutex{},
+		watchers: map[chan *syncpb.BeforeSuiteState]bool{},
+	}
+}
+
+func (s *GRPCServer) Publish(ctx context.Context, state *syncpb.BeforeSuiteState) (*syncpb.BeforeSuiteStateRequest, error) {
+	s.lock.Lock()
+	s.state = state
+	for ch := range s.watchers {
+		ch <- state
+	}
+	s.lock.Unlock()
+
+	return &syncpb.BeforeSuiteStateRequest{}, nil
+}
+
+func (s *GRPCServer) Watch(_ *syncpb.BeforeSuiteStateRequest, stream syncpb.BeforeSuiteSync_WatchServer) error {
+	ch := make(chan *syncpb.BeforeSuiteState, 1)
+
+	s.lock.Lock()
+	s.watchers[ch] = true
+	current := s.state
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		delete(s.watchers, ch)
+		s.lock.Unlock()
+	}()
+
+	if current != nil {
+		if err := stream.Send(current); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case state := <-ch:
+			if err := stream.Send(state); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// ServeGRPC starts a gRPC server hosting server on listener and blocks until
+// it stops; unlike Server.Start/StartTLS for the HTTP sync host, which serve
+// in a background goroutine and return at once.
+func ServeGRPC(listener net.Listener, server *GRPCServer, opts ...grpc.ServerOption) error {
+	grpcServer := grpc.NewServer(opts...)
+	syncpb.RegisterBeforeSuiteSyncServer(grpcServer, server)
+	return grpcServer.Serve(listener)
+}
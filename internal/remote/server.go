@@ -0,0 +1,88 @@
+package remote
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/leafnodes"
+)
+
+// Server hosts the BeforeSuiteState endpoint that node 1 publishes to and
+// every other node polls, matching the client side implemented by
+// leafnodes.HTTPTransport.
+type Server struct {
+	listener net.Listener
+
+	lock  *sync.Mutex
+	state leafnodes.RemoteState
+}
+
+func NewServer(listener net.Listener) *Server {
+	return &Server{
+		listener: listener,
+		lock:     &sync.Mutex{},
+		state:    leafnodes.RemoteState{State: leafnodes.RemoteStateStatePending},
+	}
+}
+
+func (s *Server) Start() {
+	httpServer := &http.Server{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/BeforeSuiteState", s.handleBeforeSuiteState)
+	httpServer.Handler = mux
+
+	go httpServer.Serve(s.listener)
+}
+
+// StartTLS behaves like Start, but serves over TLS using the certificate
+// built from conf. When conf.SyncRequireClientCert is set, it also requires
+// and verifies a client certificate signed by conf.SyncTLSCA, rejecting the
+// connection otherwise.
+func (s *Server) StartTLS(conf config.GinkgoConfigType) error {
+	tlsConfig, err := buildServerTLSConfig(conf)
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{TLSConfig: tlsConfig}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/BeforeSuiteState", s.handleBeforeSuiteState)
+	httpServer.Handler = mux
+
+	go httpServer.ServeTLS(s.listener, "", "")
+	return nil
+}
+
+func (s *Server) handleBeforeSuiteState(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		state := leafnodes.RemoteState{}
+		if err := json.Unmarshal(body, &state); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.lock.Lock()
+		s.state = state
+		s.lock.Unlock()
+	case http.MethodGet:
+		s.lock.Lock()
+		data := s.state.ToJSON()
+		s.lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,50 @@
+package remote
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/onsi/ginkgo/config"
+)
+
+// buildServerTLSConfig turns conf's sync TLS settings into a *tls.Config for
+// the sync host. conf.SyncTLSCert/SyncTLSKey are the server's own
+// certificate; when conf.SyncRequireClientCert is set, conf.SyncTLSCA is
+// additionally used as the pool of CAs that a connecting node's client
+// certificate must chain to.
+func buildServerTLSConfig(conf config.GinkgoConfigType) (*tls.Config, error) {
+	if conf.SyncTLSCert == "" || conf.SyncTLSKey == "" {
+		return nil, fmt.Errorf("StartTLS requires both synctlscert and synctlskey to be set")
+	}
+
+	cert, err := tls.LoadX509KeyPair(conf.SyncTLSCert, conf.SyncTLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sync server cert/key: %s", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if conf.SyncRequireClientCert {
+		if conf.SyncTLSCA == "" {
+			return nil, fmt.Errorf("syncrequireclientcert requires synctlsca to be set")
+		}
+
+		pem, err := ioutil.ReadFile(conf.SyncTLSCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sync TLS CA %s: %s", conf.SyncTLSCA, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse sync TLS CA %s", conf.SyncTLSCA)
+		}
+
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,31 @@
+package reporters
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/onsi/ginkgo/reporters/stenographer"
+	"github.com/onsi/ginkgo/types"
+)
+
+// DefaultReporter is the CLI reporter: it writes one human-readable line
+// per BeforeSuite outcome. A failure is rendered with
+// stenographer.FormatSyncFailure so a reader can tell a SyncError's code
+// and HTTP status/attempt count apart from an ordinary assertion failure at
+// a glance, instead of just seeing the generic failure message.
+type DefaultReporter struct {
+	writer io.Writer
+}
+
+func NewDefaultReporter(writer io.Writer) *DefaultReporter {
+	return &DefaultReporter{writer: writer}
+}
+
+func (r *DefaultReporter) BeforeSuiteDidRun(summary *types.SetupSummary) {
+	if summary.State == types.SpecStatePassed {
+		fmt.Fprintf(r.writer, "• [BeforeSuite] PASSED [%s]\n", summary.RunTime)
+		return
+	}
+
+	fmt.Fprintf(r.writer, "• [BeforeSuite] FAILED [%s]\n%s\n", summary.RunTime, stenographer.FormatSyncFailure(summary.Failure))
+}
@@ -0,0 +1,45 @@
+package reporters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/internal/leafnodes"
+	"github.com/onsi/ginkgo/types"
+)
+
+func TestDefaultReporterBeforeSuiteDidRunPassed(t *testing.T) {
+	buf := &bytes.Buffer{}
+	NewDefaultReporter(buf).BeforeSuiteDidRun(&types.SetupSummary{
+		State:   types.SpecStatePassed,
+		RunTime: 2 * time.Second,
+	})
+
+	got := buf.String()
+	if !strings.Contains(got, "PASSED") {
+		t.Errorf("output %q does not mention PASSED", got)
+	}
+}
+
+func TestDefaultReporterBeforeSuiteDidRunSyncFailure(t *testing.T) {
+	buf := &bytes.Buffer{}
+	NewDefaultReporter(buf).BeforeSuiteDidRun(&types.SetupSummary{
+		State: types.SpecStateFailed,
+		Failure: types.SpecFailure{
+			Message: "node 1 failed",
+			Cause: &leafnodes.SyncError{
+				Code:     leafnodes.SyncErrNode1Failed,
+				Attempts: 4,
+			},
+		},
+	})
+
+	got := buf.String()
+	for _, want := range []string{"FAILED", "SyncErrNode1Failed", "node 1 failed", "after 4 attempts"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output %q does not contain %q", got, want)
+		}
+	}
+}
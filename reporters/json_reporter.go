@@ -0,0 +1,25 @@
+package reporters
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/onsi/ginkgo/types"
+)
+
+// JSONReporter writes each BeforeSuite outcome as a line of JSON. Unlike
+// DefaultReporter, it doesn't go through stenographer.FormatSyncFailure at
+// all: summary.Failure.Cause marshals through *leafnodes.SyncError's own
+// MarshalJSON, so a consuming CI system gets the structured code/status/
+// attempts fields directly instead of having to parse a rendered string.
+type JSONReporter struct {
+	encoder *json.Encoder
+}
+
+func NewJSONReporter(writer io.Writer) *JSONReporter {
+	return &JSONReporter{encoder: json.NewEncoder(writer)}
+}
+
+func (r *JSONReporter) BeforeSuiteDidRun(summary *types.SetupSummary) {
+	r.encoder.Encode(summary)
+}
@@ -0,0 +1,48 @@
+package reporters
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/ginkgo/internal/leafnodes"
+	"github.com/onsi/ginkgo/types"
+)
+
+func TestJSONReporterBeforeSuiteDidRunSyncFailure(t *testing.T) {
+	buf := &bytes.Buffer{}
+	NewJSONReporter(buf).BeforeSuiteDidRun(&types.SetupSummary{
+		State: types.SpecStateFailed,
+		Failure: types.SpecFailure{
+			Message: "node 1 failed",
+			Cause: &leafnodes.SyncError{
+				Code:     leafnodes.SyncErrNode1Failed,
+				Attempts: 4,
+			},
+		},
+	})
+
+	var decoded struct {
+		State   types.SpecState `json:"State"`
+		Failure struct {
+			Message string `json:"Message"`
+			Cause   struct {
+				Code     string `json:"code"`
+				Attempts int    `json:"attempts"`
+			} `json:"Cause"`
+		} `json:"Failure"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal returned an error: %s", err)
+	}
+
+	if decoded.State != types.SpecStateFailed {
+		t.Errorf("State = %v, want SpecStateFailed", decoded.State)
+	}
+	if decoded.Failure.Cause.Code != "SyncErrNode1Failed" {
+		t.Errorf("Cause.code = %q, want %q", decoded.Failure.Cause.Code, "SyncErrNode1Failed")
+	}
+	if decoded.Failure.Cause.Attempts != 4 {
+		t.Errorf("Cause.attempts = %d, want 4", decoded.Failure.Cause.Attempts)
+	}
+}
@@ -0,0 +1,13 @@
+package reporters
+
+import "github.com/onsi/ginkgo/types"
+
+// Reporter is implemented by anything that wants to be told about a
+// CompoundBeforeSuite node's outcome. It is intentionally narrow - there is
+// no Suite runner in this package yet to drive the SpecWillRun/
+// SpecDidComplete style callbacks a full Ginkgo reporter would need - and
+// covers only the BeforeSuite reporting leafnodes.SuiteNode currently
+// produces.
+type Reporter interface {
+	BeforeSuiteDidRun(summary *types.SetupSummary)
+}
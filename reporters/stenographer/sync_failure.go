@@ -0,0 +1,28 @@
+package stenographer
+
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo/internal/leafnodes"
+	"github.com/onsi/ginkgo/types"
+)
+
+// FormatSyncFailure renders failure.Message together with the SyncErrCode
+// and any HTTP status/attempt count carried on its Cause, falling back to
+// the bare message when Cause isn't a *leafnodes.SyncError (an ordinary
+// assertion failure, for instance).
+func FormatSyncFailure(failure types.SpecFailure) string {
+	syncErr, ok := failure.Cause.(*leafnodes.SyncError)
+	if !ok {
+		return failure.Message
+	}
+
+	msg := fmt.Sprintf("%s: %s", syncErr.Code, failure.Message)
+	if syncErr.StatusCode != 0 {
+		msg += fmt.Sprintf(" [status %d]", syncErr.StatusCode)
+	}
+	if syncErr.Attempts != 0 {
+		msg += fmt.Sprintf(" [after %d attempts]", syncErr.Attempts)
+	}
+	return msg
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"time"
+)
+
+type SpecState uint
+
+const (
+	SpecStateInvalid SpecState = iota
+
+	SpecStatePending
+	SpecStateSkipped
+	SpecStatePassed
+	SpecStateFailed
+	SpecStatePanicked
+	SpecStateTimedOut
+	SpecStateInterrupted
+)
+
+type SpecFailure struct {
+	Message       string
+	Location      CodeLocation
+	ForwardedFrom CodeLocation
+
+	ComponentType         SpecComponentType
+	ComponentIndex        int
+	ComponentCodeLocation CodeLocation
+
+	// Cause carries a structured, typed error underlying Message - e.g. a
+	// *leafnodes.SyncError - for failures whose root cause reporters or CI
+	// systems need to distinguish programmatically. It is nil for ordinary
+	// assertion failures.
+	Cause error
+}
+
+type SpecComponentType uint
+
+const (
+	SpecComponentTypeInvalid SpecComponentType = iota
+
+	SpecComponentTypeContainer
+	SpecComponentTypeBeforeSuite
+	SpecComponentTypeAfterSuite
+	SpecComponentTypeBeforeEach
+	SpecComponentTypeJustBeforeEach
+	SpecComponentTypeAfterEach
+	SpecComponentTypeIt
+	SpecComponentTypeMeasure
+)
+
+type SetupSummary struct {
+	ComponentType SpecComponentType
+	CodeLocation  CodeLocation
+
+	State   SpecState
+	RunTime time.Duration
+	Failure SpecFailure
+
+	CapturedOutput string
+	SuiteID        string
+}
+
+type CodeLocation struct {
+	FileName       string
+	LineNumber     int
+	FullStackTrace string
+}